@@ -2,6 +2,7 @@ package bedrockchat
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
@@ -50,7 +51,13 @@ func newClient(opts ...Option) (*options, *bedrockclient.Client, error) {
 		options.client = bedrockruntime.NewFromConfig(cfg)
 	}
 
-	return options, bedrockclient.NewClient(options.client), nil
+	onRetry := func(ctx context.Context, attempt int, err error) {
+		if options.callbackHandler != nil {
+			options.callbackHandler.HandleText(ctx, fmt.Sprintf("bedrock: retrying after attempt %d: %s", attempt, err))
+		}
+	}
+
+	return options, bedrockclient.NewClient(options.client, options.retryPolicy, onRetry), nil
 }
 
 // Call implements llms.Model.
@@ -71,6 +78,16 @@ func (l *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		opt(&opts)
 	}
 
+	if opts.StreamingFunc != nil {
+		streamingFunc := opts.StreamingFunc
+		opts.StreamingFunc = func(ctx context.Context, chunk []byte) error {
+			if l.CallbacksHandler != nil {
+				l.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+			}
+			return streamingFunc(ctx, chunk)
+		}
+	}
+
 	res, err := l.client.CreateCompletion(ctx, opts.Model, messages, opts)
 	if err != nil {
 		if l.CallbacksHandler != nil {
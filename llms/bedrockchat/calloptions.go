@@ -0,0 +1,67 @@
+package bedrockchat
+
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/bedrockchat/internal/bedrockclient"
+)
+
+// BedrockGuardrailError is returned when a configured guardrail blocks or
+// otherwise intervenes on a Converse call. Callers can inspect Trace for
+// the policy assessments that triggered it.
+type BedrockGuardrailError = bedrockclient.BedrockGuardrailError
+
+// WithGuardrail configures the Bedrock guardrail to apply to a Converse
+// call. Set trace to true to have Bedrock return the policy assessments
+// behind any intervention on BedrockGuardrailError.Trace.
+func WithGuardrail(identifier, version string, trace bool) llms.CallOption {
+	return func(o *llms.CallOptions) {
+		extra := extraOptions(o)
+		extra.GuardrailIdentifier = identifier
+		extra.GuardrailVersion = version
+		extra.GuardrailTrace = trace
+		setExtraOptions(o, extra)
+	}
+}
+
+// WithAdditionalModelRequestFields sets model-specific request fields not
+// covered by llms.CallOptions, e.g. Claude's "top_k" or Nova's
+// "inferenceConfig.reasoning_config".
+func WithAdditionalModelRequestFields(fields map[string]any) llms.CallOption {
+	return func(o *llms.CallOptions) {
+		extra := extraOptions(o)
+		extra.AdditionalModelRequestFields = fields
+		setExtraOptions(o, extra)
+	}
+}
+
+// WithPromptVariables sets the variables to substitute into a Bedrock Prompt
+// Management template referenced by the call.
+func WithPromptVariables(variables map[string]string) llms.CallOption {
+	return func(o *llms.CallOptions) {
+		extra := extraOptions(o)
+		extra.PromptVariables = variables
+		setExtraOptions(o, extra)
+	}
+}
+
+// WithRequestMetadata attaches free-form key/value metadata to a Converse
+// call, surfaced in Bedrock-side request logs.
+func WithRequestMetadata(metadata map[string]string) llms.CallOption {
+	return func(o *llms.CallOptions) {
+		extra := extraOptions(o)
+		extra.RequestMetadata = metadata
+		setExtraOptions(o, extra)
+	}
+}
+
+func extraOptions(o *llms.CallOptions) bedrockclient.ExtraOptions {
+	extra, _ := o.Metadata[bedrockclient.MetadataKey].(bedrockclient.ExtraOptions)
+	return extra
+}
+
+func setExtraOptions(o *llms.CallOptions, extra bedrockclient.ExtraOptions) {
+	if o.Metadata == nil {
+		o.Metadata = map[string]any{}
+	}
+	o.Metadata[bedrockclient.MetadataKey] = extra
+}
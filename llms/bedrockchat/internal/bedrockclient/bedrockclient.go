@@ -4,25 +4,44 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go/document"
 	"github.com/tmc/langchaingo/llms"
 )
 
+// converseAPIClient is the subset of *bedrockruntime.Client that Client
+// depends on, extracted so tests can substitute a fake implementation.
+type converseAPIClient interface {
+	Converse(ctx context.Context, params *bedrockruntime.ConverseInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseOutput, error)
+	ConverseStream(ctx context.Context, params *bedrockruntime.ConverseStreamInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseStreamOutput, error)
+}
+
 // Client is a Bedrock client.
 type Client struct {
-	client *bedrockruntime.Client
+	client      converseAPIClient
+	retryPolicy RetryPolicy
+	onRetry     RetryHandler
 }
 
+// RetryHandler is notified before each retry attempt, before the backoff
+// delay is computed and waited out.
+type RetryHandler func(ctx context.Context, attempt int, err error)
+
 // NewClient creates a new Bedrock client.
-func NewClient(client *bedrockruntime.Client) *Client {
+func NewClient(client *bedrockruntime.Client, retryPolicy RetryPolicy, onRetry RetryHandler) *Client {
 	return &Client{
-		client: client,
+		client:      client,
+		retryPolicy: retryPolicy,
+		onRetry:     onRetry,
 	}
 }
 
@@ -33,42 +52,36 @@ func (c *Client) CreateCompletion(ctx context.Context,
 	messages []llms.MessageContent,
 	options llms.CallOptions,
 ) (*llms.ContentResponse, error) {
-	inferenceConfig := &types.InferenceConfiguration{
-		MaxTokens:     aws.Int32(int32(getMaxTokens(options.MaxTokens, 512))),
-		TopP:          aws.Float32(float32(options.TopP)),
-		Temperature:   aws.Float32(float32(options.Temperature)),
-		StopSequences: options.StopWords,
-	}
-
-	systemMessages, otherMessages := []llms.MessageContent{}, []llms.MessageContent{}
-	for _, m := range messages {
-		if m.Role == llms.ChatMessageTypeSystem {
-			systemMessages = append(systemMessages, m)
-		} else {
-			otherMessages = append(otherMessages, m)
-		}
-	}
-
-	systemPrompt, err := processSystemMessages(systemMessages)
+	input, err := buildConverseInput(modelID, messages, options)
 	if err != nil {
 		return nil, err
 	}
 
-	m, err := processMessages(otherMessages)
-	if err != nil {
-		return nil, err
+	if options.StreamingFunc != nil {
+		var res *llms.ContentResponse
+		retryErr := c.withRetry(ctx, func() error {
+			streamRes, streamErr := c.createCompletionStream(ctx, input, options)
+			res = streamRes
+			return streamErr
+		})
+		return res, retryErr
 	}
 
-	input := &bedrockruntime.ConverseInput{
-		ModelId:         aws.String(modelID),
-		Messages:        m,
-		InferenceConfig: inferenceConfig,
-		System:          systemPrompt,
+	var output *bedrockruntime.ConverseOutput
+	retryErr := c.withRetry(ctx, func() error {
+		var converseErr error
+		output, converseErr = c.client.Converse(ctx, input)
+		return converseErr
+	})
+	if retryErr != nil {
+		return nil, retryErr
 	}
 
-	output, err := c.client.Converse(ctx, input)
-	if err != nil {
-		return nil, err
+	if output.StopReason == types.StopReasonGuardrailIntervened {
+		return nil, &BedrockGuardrailError{
+			StopReason: string(output.StopReason),
+			Trace:      output.Trace,
+		}
 	}
 
 	// according to the docs this is always what is returned
@@ -78,6 +91,7 @@ func (c *Client) CreateCompletion(ctx context.Context,
 	}
 
 	outputContents := []string{}
+	var toolCalls []llms.ToolCall
 	for _, content := range outputMessage.Value.Content {
 		switch typedContent := content.(type) {
 		case *types.ContentBlockMemberText:
@@ -87,6 +101,12 @@ func (c *Client) CreateCompletion(ctx context.Context,
 			if imageSourceBytes, ok := imageSource.(*types.ImageSourceMemberBytes); ok {
 				outputContents = append(outputContents, string(imageSourceBytes.Value))
 			}
+		case *types.ContentBlockMemberToolUse:
+			toolCall, err := toolUseToToolCall(typedContent.Value)
+			if err != nil {
+				return nil, err
+			}
+			toolCalls = append(toolCalls, toolCall)
 		}
 	}
 
@@ -95,6 +115,7 @@ func (c *Client) CreateCompletion(ctx context.Context,
 			{
 				Content:    strings.Join(outputContents, "\n"),
 				StopReason: string(output.StopReason),
+				ToolCalls:  toolCalls,
 				GenerationInfo: map[string]any{
 					"input_tokens":  output.Usage.InputTokens,
 					"output_tokens": output.Usage.OutputTokens,
@@ -104,6 +125,179 @@ func (c *Client) CreateCompletion(ctx context.Context,
 	}, nil
 }
 
+// createCompletionStream invokes ConverseStream and feeds each text delta
+// through options.StreamingFunc as it arrives, aggregating the deltas and
+// the terminating MessageStop/Metadata events into a single ContentResponse.
+func (c *Client) createCompletionStream(ctx context.Context,
+	input *bedrockruntime.ConverseInput,
+	options llms.CallOptions,
+) (*llms.ContentResponse, error) {
+	output, err := c.client.ConverseStream(ctx, &bedrockruntime.ConverseStreamInput{
+		ModelId:                      input.ModelId,
+		Messages:                     input.Messages,
+		InferenceConfig:              input.InferenceConfig,
+		System:                       input.System,
+		ToolConfig:                   input.ToolConfig,
+		GuardrailConfig:              converseGuardrailConfigToStream(input.GuardrailConfig),
+		AdditionalModelRequestFields: input.AdditionalModelRequestFields,
+		PromptVariables:              input.PromptVariables,
+		RequestMetadata:              input.RequestMetadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stream := output.GetStream()
+	defer stream.Close()
+
+	var content strings.Builder
+	var stopReason string
+	var inputTokens, outputTokens int32
+	var guardrailTrace *types.GuardrailTraceAssessment
+	var toolCalls []llms.ToolCall
+	toolUses := map[int32]*toolUseBuilder{}
+	// delivered tracks whether any text has already reached the caller's
+	// StreamingFunc. Once that happens the stream can no longer be safely
+	// retried from scratch: the caller would see earlier tokens twice with
+	// no way to tell that a restart occurred.
+	var delivered bool
+
+	for event := range stream.Events() {
+		switch e := event.(type) {
+		case *types.ConverseStreamOutputMemberContentBlockStart:
+			if toolUseStart, ok := e.Value.Start.(*types.ContentBlockStartMemberToolUse); ok {
+				toolUses[aws.ToInt32(e.Value.ContentBlockIndex)] = &toolUseBuilder{
+					id:   aws.ToString(toolUseStart.Value.ToolUseId),
+					name: aws.ToString(toolUseStart.Value.Name),
+				}
+			}
+		case *types.ConverseStreamOutputMemberContentBlockDelta:
+			switch delta := e.Value.Delta.(type) {
+			case *types.ContentBlockDeltaMemberText:
+				content.WriteString(delta.Value)
+				delivered = true
+				if err := options.StreamingFunc(ctx, []byte(delta.Value)); err != nil {
+					return nil, err
+				}
+			case *types.ContentBlockDeltaMemberToolUse:
+				if b, ok := toolUses[aws.ToInt32(e.Value.ContentBlockIndex)]; ok && delta.Value.Input != nil {
+					b.input.WriteString(*delta.Value.Input)
+				}
+			}
+		case *types.ConverseStreamOutputMemberContentBlockStop:
+			index := aws.ToInt32(e.Value.ContentBlockIndex)
+			if b, ok := toolUses[index]; ok {
+				toolCalls = append(toolCalls, b.toolCall())
+				delete(toolUses, index)
+			}
+		case *types.ConverseStreamOutputMemberMessageStop:
+			stopReason = string(e.Value.StopReason)
+		case *types.ConverseStreamOutputMemberMetadata:
+			if e.Value.Usage != nil {
+				inputTokens = e.Value.Usage.InputTokens
+				outputTokens = e.Value.Usage.OutputTokens
+			}
+			if e.Value.Trace != nil {
+				guardrailTrace = e.Value.Trace.GuardrailTrace
+			}
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		if delivered {
+			// Bytes already reached the caller: surface the error as
+			// non-retryable so withRetry doesn't replay them from scratch.
+			return nil, &nonRetryableStreamError{err: err}
+		}
+		return nil, err
+	}
+
+	if stopReason == string(types.StopReasonGuardrailIntervened) {
+		return nil, &BedrockGuardrailError{
+			StopReason: stopReason,
+			Trace:      &types.ConverseTrace{GuardrailTrace: guardrailTrace},
+		}
+	}
+
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{
+			{
+				Content:    content.String(),
+				StopReason: stopReason,
+				ToolCalls:  toolCalls,
+				GenerationInfo: map[string]any{
+					"input_tokens":  inputTokens,
+					"output_tokens": outputTokens,
+				},
+			},
+		},
+	}, nil
+}
+
+func buildConverseInput(
+	modelID string,
+	messages []llms.MessageContent,
+	options llms.CallOptions,
+) (*bedrockruntime.ConverseInput, error) {
+	inferenceConfig := &types.InferenceConfiguration{
+		MaxTokens:     aws.Int32(int32(getMaxTokens(options.MaxTokens, 512))),
+		TopP:          aws.Float32(float32(options.TopP)),
+		Temperature:   aws.Float32(float32(options.Temperature)),
+		StopSequences: options.StopWords,
+	}
+
+	systemMessages, otherMessages := []llms.MessageContent{}, []llms.MessageContent{}
+	for _, m := range messages {
+		if m.Role == llms.ChatMessageTypeSystem {
+			systemMessages = append(systemMessages, m)
+		} else {
+			otherMessages = append(otherMessages, m)
+		}
+	}
+
+	systemPrompt, err := processSystemMessages(systemMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := processMessages(otherMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	toolConfig, err := toolsToBedrockToolConfig(options.Tools, options.ToolChoice)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := extraOptionsFrom(options)
+
+	var additionalModelRequestFields document.Interface
+	if len(extra.AdditionalModelRequestFields) > 0 {
+		additionalModelRequestFields = document.NewLazyDocument(extra.AdditionalModelRequestFields)
+	}
+
+	var promptVariables map[string]types.PromptVariableValues
+	if len(extra.PromptVariables) > 0 {
+		promptVariables = make(map[string]types.PromptVariableValues, len(extra.PromptVariables))
+		for k, v := range extra.PromptVariables {
+			promptVariables[k] = &types.PromptVariableValuesMemberText{Value: v}
+		}
+	}
+
+	return &bedrockruntime.ConverseInput{
+		ModelId:                      aws.String(modelID),
+		Messages:                     m,
+		InferenceConfig:              inferenceConfig,
+		System:                       systemPrompt,
+		ToolConfig:                   toolConfig,
+		GuardrailConfig:              guardrailConfigFrom(extra),
+		AdditionalModelRequestFields: additionalModelRequestFields,
+		PromptVariables:              promptVariables,
+		RequestMetadata:              extra.RequestMetadata,
+	}, nil
+}
+
 func processSystemMessages(messages []llms.MessageContent) ([]types.SystemContentBlock, error) {
 	if len(messages) == 0 {
 		return nil, nil
@@ -158,6 +352,10 @@ func roleToBedrockRole(role llms.ChatMessageType) (types.ConversationRole, error
 		return types.ConversationRoleUser, nil
 	case llms.ChatMessageTypeAI:
 		return types.ConversationRoleAssistant, nil
+	case llms.ChatMessageTypeTool:
+		// Bedrock has no separate tool role: a ToolResultBlock is sent back
+		// as part of a user-role message.
+		return types.ConversationRoleUser, nil
 	}
 	return "", fmt.Errorf("unsupported role: %s", role)
 }
@@ -173,10 +371,157 @@ func messageToBedrockContent(content llms.ContentPart) (types.ContentBlock, erro
 		return binaryContentToBedrockContent(typedContent)
 	case llms.ImageURLContent:
 		return imageURLContentToBedrockContent(typedContent)
+	case llms.ToolCall:
+		return toolCallToBedrockContent(typedContent)
+	case llms.ToolCallResponse:
+		return toolCallResponseToBedrockContent(typedContent)
 	}
 	return nil, fmt.Errorf("unsupported content type: %T", content)
 }
 
+func toolCallToBedrockContent(toolCall llms.ToolCall) (types.ContentBlock, error) {
+	var input map[string]any
+	if args := toolCall.FunctionCall.Arguments; args != "" {
+		if err := json.Unmarshal([]byte(args), &input); err != nil {
+			return nil, fmt.Errorf("unmarshal tool call arguments: %w", err)
+		}
+	}
+
+	return &types.ContentBlockMemberToolUse{
+		Value: types.ToolUseBlock{
+			ToolUseId: aws.String(toolCall.ID),
+			Name:      aws.String(toolCall.FunctionCall.Name),
+			Input:     document.NewLazyDocument(input),
+		},
+	}, nil
+}
+
+func toolCallResponseToBedrockContent(response llms.ToolCallResponse) (types.ContentBlock, error) {
+	return &types.ContentBlockMemberToolResult{
+		Value: types.ToolResultBlock{
+			ToolUseId: aws.String(response.ToolCallID),
+			Content: []types.ToolResultContentBlock{
+				&types.ToolResultContentBlockMemberText{
+					Value: response.Content,
+				},
+			},
+		},
+	}, nil
+}
+
+// toolUseBuilder accumulates a tool_use content block across the
+// ContentBlockStart/Delta/Stop events of a ConverseStream, since Bedrock
+// streams the tool input as successive partial-JSON fragments.
+type toolUseBuilder struct {
+	id    string
+	name  string
+	input strings.Builder
+}
+
+func (b *toolUseBuilder) toolCall() llms.ToolCall {
+	arguments := b.input.String()
+	if arguments == "" {
+		arguments = "{}"
+	}
+
+	return llms.ToolCall{
+		ID:   b.id,
+		Type: "function",
+		FunctionCall: &llms.FunctionCall{
+			Name:      b.name,
+			Arguments: arguments,
+		},
+	}
+}
+
+// toolUseToToolCall translates a Bedrock ToolUseBlock into the llms.ToolCall
+// shape used by the rest of the library so an agent loop can dispatch it.
+func toolUseToToolCall(toolUse types.ToolUseBlock) (llms.ToolCall, error) {
+	var input any
+	if toolUse.Input != nil {
+		if err := toolUse.Input.UnmarshalDocument(&input); err != nil {
+			return llms.ToolCall{}, fmt.Errorf("unmarshal tool use input: %w", err)
+		}
+	}
+
+	arguments, err := json.Marshal(input)
+	if err != nil {
+		return llms.ToolCall{}, fmt.Errorf("marshal tool use input: %w", err)
+	}
+
+	return llms.ToolCall{
+		ID:   aws.ToString(toolUse.ToolUseId),
+		Type: "function",
+		FunctionCall: &llms.FunctionCall{
+			Name:      aws.ToString(toolUse.Name),
+			Arguments: string(arguments),
+		},
+	}, nil
+}
+
+// toolsToBedrockToolConfig translates llms.Tool/ToolChoice options into a
+// Bedrock ToolConfiguration. It returns nil when no tools were requested.
+func toolsToBedrockToolConfig(tools []llms.Tool, toolChoice any) (*types.ToolConfiguration, error) {
+	if len(tools) == 0 {
+		return nil, nil
+	}
+
+	bedrockTools := make([]types.Tool, len(tools))
+	for i, tool := range tools {
+		if tool.Function == nil {
+			return nil, fmt.Errorf("unsupported tool type: %s", tool.Type)
+		}
+
+		bedrockTools[i] = &types.ToolMemberToolSpec{
+			Value: types.ToolSpecification{
+				Name:        aws.String(tool.Function.Name),
+				Description: aws.String(tool.Function.Description),
+				InputSchema: &types.ToolInputSchemaMemberJson{
+					Value: document.NewLazyDocument(tool.Function.Parameters),
+				},
+			},
+		}
+	}
+
+	choice, err := toBedrockToolChoice(toolChoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ToolConfiguration{
+		Tools:      bedrockTools,
+		ToolChoice: choice,
+	}, nil
+}
+
+func toBedrockToolChoice(toolChoice any) (types.ToolChoice, error) {
+	switch choice := toolChoice.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		switch choice {
+		case "auto", "":
+			return &types.ToolChoiceMemberAuto{}, nil
+		case "any", "required":
+			return &types.ToolChoiceMemberAny{}, nil
+		default:
+			return &types.ToolChoiceMemberTool{
+				Value: types.SpecificToolChoice{Name: aws.String(choice)},
+			}, nil
+		}
+	case map[string]any:
+		function, _ := choice["function"].(map[string]any)
+		name, _ := function["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("unsupported tool choice: %v", choice)
+		}
+		return &types.ToolChoiceMemberTool{
+			Value: types.SpecificToolChoice{Name: aws.String(name)},
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported tool choice type: %T", toolChoice)
+}
+
 func imageURLContentToBedrockContent(content llms.ImageURLContent) (types.ContentBlock, error) {
 	parts := strings.Split(content.URL, ";")
 	if len(parts) != 2 {
@@ -287,3 +632,161 @@ func getMaxTokens(maxTokens, defaultValue int) int {
 	}
 	return maxTokens
 }
+
+// MetadataKey is the llms.CallOptions.Metadata key under which Bedrock
+// stashes the ExtraOptions for the current call. It's exported so the
+// bedrockchat package can populate it from its WithXXX CallOption helpers.
+const MetadataKey = "bedrock"
+
+// ExtraOptions carries Bedrock-specific per-call configuration that isn't
+// covered by the generic llms.CallOptions, threaded through Converse calls
+// via llms.CallOptions.Metadata[MetadataKey].
+type ExtraOptions struct {
+	GuardrailIdentifier          string
+	GuardrailVersion             string
+	GuardrailTrace               bool
+	AdditionalModelRequestFields map[string]any
+	PromptVariables              map[string]string
+	RequestMetadata              map[string]string
+}
+
+func extraOptionsFrom(options llms.CallOptions) ExtraOptions {
+	extra, _ := options.Metadata[MetadataKey].(ExtraOptions)
+	return extra
+}
+
+func guardrailConfigFrom(extra ExtraOptions) *types.GuardrailConfiguration {
+	if extra.GuardrailIdentifier == "" {
+		return nil
+	}
+
+	trace := types.GuardrailTraceDisabled
+	if extra.GuardrailTrace {
+		trace = types.GuardrailTraceEnabled
+	}
+
+	return &types.GuardrailConfiguration{
+		GuardrailIdentifier: aws.String(extra.GuardrailIdentifier),
+		GuardrailVersion:    aws.String(extra.GuardrailVersion),
+		Trace:               trace,
+	}
+}
+
+func converseGuardrailConfigToStream(config *types.GuardrailConfiguration) *types.GuardrailStreamConfiguration {
+	if config == nil {
+		return nil
+	}
+
+	return &types.GuardrailStreamConfiguration{
+		GuardrailIdentifier: config.GuardrailIdentifier,
+		GuardrailVersion:    config.GuardrailVersion,
+		Trace:               types.GuardrailTrace(config.Trace),
+	}
+}
+
+// BedrockGuardrailError is returned when a configured guardrail blocks or
+// otherwise intervenes on a Converse call. Trace carries the policy
+// assessments that triggered it, present when guardrail tracing was
+// requested.
+type BedrockGuardrailError struct {
+	StopReason string
+	Trace      *types.ConverseTrace
+}
+
+func (e *BedrockGuardrailError) Error() string {
+	return fmt.Sprintf("bedrockclient: guardrail intervened (stop reason %q)", e.StopReason)
+}
+
+// RetryPolicy configures retry/backoff behavior for Converse calls that fail
+// with a transient Bedrock error (throttling or a transient service error).
+// A zero value disables retries: MaxAttempts <= 1 means every call is tried
+// exactly once.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Jitter is the fraction (0-1) of the computed backoff to randomly add
+	// or subtract, to avoid retry storms across concurrent callers.
+	Jitter float64
+}
+
+// withRetry runs fn, retrying it while it fails with a transient Bedrock
+// error, up to retryPolicy.MaxAttempts. It waits out the backoff between
+// attempts on a timer that is stopped as soon as ctx is done or the timer
+// fires, and returns ctx.Err() if the context is cancelled while waiting.
+func (c *Client) withRetry(ctx context.Context, fn func() error) error {
+	maxAttempts := c.retryPolicy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = fn()
+		if err == nil || !isTransientError(err) || attempt == maxAttempts {
+			return err
+		}
+
+		if c.onRetry != nil {
+			c.onRetry(ctx, attempt, err)
+		}
+
+		if sleepErr := sleepContext(ctx, c.backoff(attempt)); sleepErr != nil {
+			return sleepErr
+		}
+	}
+	return err
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.retryPolicy.InitialBackoff * time.Duration(int64(1)<<uint(attempt-1))
+	if c.retryPolicy.MaxBackoff > 0 && d > c.retryPolicy.MaxBackoff {
+		d = c.retryPolicy.MaxBackoff
+	}
+	if c.retryPolicy.Jitter <= 0 {
+		return d
+	}
+	delta := float64(d) * c.retryPolicy.Jitter * (2*rand.Float64() - 1) //nolint:gosec
+	return d + time.Duration(delta)
+}
+
+// sleepContext waits for d, returning early with ctx.Err() if ctx is done
+// first. The timer is always stopped before returning.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isTransientError(err error) bool {
+	var nonRetryable *nonRetryableStreamError
+	if errors.As(err, &nonRetryable) {
+		return false
+	}
+
+	var throttling *types.ThrottlingException
+	var modelStreamErr *types.ModelStreamErrorException
+	var serviceUnavailable *types.ServiceUnavailableException
+	var internalServerErr *types.InternalServerException
+	return errors.As(err, &throttling) ||
+		errors.As(err, &modelStreamErr) ||
+		errors.As(err, &serviceUnavailable) ||
+		errors.As(err, &internalServerErr)
+}
+
+// nonRetryableStreamError wraps a transient Bedrock error that occurred
+// after some stream content had already been delivered to the caller's
+// StreamingFunc, so withRetry must not retry it even though the underlying
+// error is otherwise transient.
+type nonRetryableStreamError struct {
+	err error
+}
+
+func (e *nonRetryableStreamError) Error() string { return e.err.Error() }
+func (e *nonRetryableStreamError) Unwrap() error { return e.err }
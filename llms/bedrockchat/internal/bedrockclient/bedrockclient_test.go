@@ -0,0 +1,136 @@
+package bedrockclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go/document"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// fakeConverseAPIClient is a canned converseAPIClient for exercising
+// Client.CreateCompletion without talking to Bedrock.
+type fakeConverseAPIClient struct {
+	converseFunc func(ctx context.Context, params *bedrockruntime.ConverseInput) (*bedrockruntime.ConverseOutput, error)
+}
+
+func (f *fakeConverseAPIClient) Converse(
+	ctx context.Context,
+	params *bedrockruntime.ConverseInput,
+	_ ...func(*bedrockruntime.Options),
+) (*bedrockruntime.ConverseOutput, error) {
+	return f.converseFunc(ctx, params)
+}
+
+func (f *fakeConverseAPIClient) ConverseStream(
+	context.Context,
+	*bedrockruntime.ConverseStreamInput,
+	...func(*bedrockruntime.Options),
+) (*bedrockruntime.ConverseStreamOutput, error) {
+	panic("not implemented")
+}
+
+func TestCreateCompletionToolUseRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var gotToolConfig *types.ToolConfiguration
+	fake := &fakeConverseAPIClient{
+		converseFunc: func(_ context.Context, params *bedrockruntime.ConverseInput) (*bedrockruntime.ConverseOutput, error) {
+			gotToolConfig = params.ToolConfig
+			return &bedrockruntime.ConverseOutput{
+				StopReason: types.StopReasonToolUse,
+				Output: &types.ConverseOutputMemberMessage{
+					Value: types.Message{
+						Role: types.ConversationRoleAssistant,
+						Content: []types.ContentBlock{
+							&types.ContentBlockMemberToolUse{
+								Value: types.ToolUseBlock{
+									ToolUseId: aws.String("tool-1"),
+									Name:      aws.String("get_weather"),
+									Input:     document.NewLazyDocument(map[string]any{"city": "nyc"}),
+								},
+							},
+						},
+					},
+				},
+				Usage: &types.TokenUsage{InputTokens: 10, OutputTokens: 5},
+			}, nil
+		},
+	}
+
+	client := &Client{client: fake}
+
+	options := llms.CallOptions{
+		Tools: []llms.Tool{
+			{
+				Type: "function",
+				Function: &llms.FunctionDefinition{
+					Name:        "get_weather",
+					Description: "gets the weather for a city",
+					Parameters:  map[string]any{"type": "object"},
+				},
+			},
+		},
+	}
+
+	res, err := client.CreateCompletion(context.Background(), "anthropic.claude-3", []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "what's the weather in nyc?"),
+	}, options)
+	if err != nil {
+		t.Fatalf("CreateCompletion: %v", err)
+	}
+
+	if gotToolConfig == nil || len(gotToolConfig.Tools) != 1 {
+		t.Fatalf("expected a tool config with one tool, got %+v", gotToolConfig)
+	}
+
+	if len(res.Choices) != 1 || len(res.Choices[0].ToolCalls) != 1 {
+		t.Fatalf("expected exactly one tool call, got %+v", res.Choices)
+	}
+
+	toolCall := res.Choices[0].ToolCalls[0]
+	if toolCall.ID != "tool-1" || toolCall.FunctionCall.Name != "get_weather" {
+		t.Fatalf("unexpected tool call: %+v", toolCall)
+	}
+	if toolCall.FunctionCall.Arguments != `{"city":"nyc"}` {
+		t.Fatalf("unexpected tool call arguments: %s", toolCall.FunctionCall.Arguments)
+	}
+}
+
+func TestProcessMessagesToolCallResponse(t *testing.T) {
+	t.Parallel()
+
+	messages, err := processMessages([]llms.MessageContent{
+		{
+			Role: llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{
+				llms.ToolCallResponse{
+					ToolCallID: "tool-1",
+					Name:       "get_weather",
+					Content:    `{"temp_f":72}`,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("processMessages: %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("expected a single message, got %d", len(messages))
+	}
+	if messages[0].Role != types.ConversationRoleUser {
+		t.Fatalf("expected tool results to be sent as the user role, got %s", messages[0].Role)
+	}
+
+	toolResult, ok := messages[0].Content[0].(*types.ContentBlockMemberToolResult)
+	if !ok {
+		t.Fatalf("expected a tool result content block, got %T", messages[0].Content[0])
+	}
+	if aws.ToString(toolResult.Value.ToolUseId) != "tool-1" {
+		t.Fatalf("unexpected tool use id: %s", aws.ToString(toolResult.Value.ToolUseId))
+	}
+}
@@ -0,0 +1,152 @@
+package bedrockclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		failures     int
+		err          error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "succeeds on first attempt",
+			failures:     0,
+			err:          &types.ThrottlingException{},
+			wantAttempts: 1,
+		},
+		{
+			name:         "retries transient errors until success",
+			failures:     2,
+			err:          &types.ThrottlingException{},
+			wantAttempts: 3,
+		},
+		{
+			name:         "gives up after MaxAttempts",
+			failures:     5,
+			err:          &types.ServiceUnavailableException{},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "does not retry non-transient errors",
+			failures:     5,
+			err:          errors.New("boom"),
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts, retryNotifications int
+			client := &Client{
+				retryPolicy: RetryPolicy{
+					MaxAttempts:    3,
+					InitialBackoff: time.Millisecond,
+					MaxBackoff:     5 * time.Millisecond,
+				},
+				onRetry: func(context.Context, int, error) {
+					retryNotifications++
+				},
+			}
+
+			err := client.withRetry(context.Background(), func() error {
+				attempts++
+				if attempts <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+
+			if attempts != tt.wantAttempts {
+				t.Fatalf("expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error state: %v", err)
+			}
+			if retryNotifications != attempts-1 {
+				t.Fatalf("expected %d retry notifications, got %d", attempts-1, retryNotifications)
+			}
+		})
+	}
+}
+
+func TestWithRetryStopsWhenContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := &Client{
+		retryPolicy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+	}
+
+	var attempts int
+	err := client.withRetry(ctx, func() error {
+		attempts++
+		return &types.ThrottlingException{}
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly one attempt before the cancelled sleep aborted the retry, got %d", attempts)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"throttling", &types.ThrottlingException{}, true},
+		{"model stream error", &types.ModelStreamErrorException{}, true},
+		{"service unavailable", &types.ServiceUnavailableException{}, true},
+		{"internal server error", &types.InternalServerException{}, true},
+		{"generic error", errors.New("boom"), false},
+		{"wrapped non-retryable stream error", &nonRetryableStreamError{err: &types.ThrottlingException{}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Fatalf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffCapsAtMaxBackoff(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		retryPolicy: RetryPolicy{
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     25 * time.Millisecond,
+		},
+	}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		if d := client.backoff(attempt); d > client.retryPolicy.MaxBackoff {
+			t.Fatalf("attempt %d: backoff %s exceeds MaxBackoff %s", attempt, d, client.retryPolicy.MaxBackoff)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package bedrockchat
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms/bedrockchat/internal/bedrockclient"
+)
+
+type options struct {
+	modelID         string
+	client          *bedrockruntime.Client
+	callbackHandler callbacks.Handler
+	retryPolicy     bedrockclient.RetryPolicy
+}
+
+// Option is an option for the Bedrock LLM.
+type Option func(*options)
+
+// WithModel allows setting a custom modelID.
+func WithModel(modelID string) Option {
+	return func(o *options) {
+		o.modelID = modelID
+	}
+}
+
+// WithClient allows setting a custom bedrockruntime.Client.
+func WithClient(client *bedrockruntime.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithCallback allows setting a custom Callback Handler.
+func WithCallback(callbackHandler callbacks.Handler) Option {
+	return func(o *options) {
+		o.callbackHandler = callbackHandler
+	}
+}
+
+// RetryPolicy configures retry/backoff for Converse calls that fail with a
+// transient Bedrock error, such as throttling.
+type RetryPolicy = bedrockclient.RetryPolicy
+
+// WithRetryPolicy configures retry/backoff for transient Bedrock errors.
+// By default (the zero RetryPolicy) calls are not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
@@ -0,0 +1,63 @@
+// Package bedrockembed provides an embeddings.EmbedderClient implementation
+// backed by Amazon Bedrock embedding models (Titan Embed, Cohere Embed).
+package bedrockembed
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/llms/bedrockembed/internal/bedrockclient"
+)
+
+const defaultModel = "amazon.titan-embed-text-v2:0"
+
+// Client is a Bedrock embeddings client.
+type Client struct {
+	client *bedrockclient.Client
+}
+
+// New creates a new Bedrock embeddings client.
+func New(opts ...Option) (*Client, error) {
+	o := &options{
+		modelID: defaultModel,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.client == nil {
+		cfg, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		o.client = bedrockruntime.NewFromConfig(cfg)
+	}
+
+	return &Client{
+		client: bedrockclient.NewClient(o.client, o.modelID, o.inputType, o.maxBatchSize),
+	}, nil
+}
+
+// Usage reports the token usage billed for an embeddings call.
+type Usage = bedrockclient.Usage
+
+// CreateEmbedding implements embeddings.EmbedderClient.
+func (c *Client) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors, _, err := c.client.CreateEmbedding(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
+
+// CreateEmbeddingWithUsage behaves like CreateEmbedding but also returns the
+// token usage billed across the underlying (possibly batched) InvokeModel
+// calls, for callers that need to track Bedrock spend.
+func (c *Client) CreateEmbeddingWithUsage(ctx context.Context, texts []string) ([][]float32, Usage, error) {
+	return c.client.CreateEmbedding(ctx, texts)
+}
+
+var _ embeddings.EmbedderClient = (*Client)(nil)
@@ -0,0 +1,152 @@
+// Package bedrockclient translates embeddings requests into the raw
+// InvokeModel JSON bodies expected by Bedrock's embedding models.
+package bedrockclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+)
+
+const (
+	defaultTitanBatchSize  = 1
+	defaultCohereBatchSize = 96
+)
+
+// Client is a Bedrock embeddings client.
+type Client struct {
+	client       *bedrockruntime.Client
+	modelID      string
+	inputType    string
+	maxBatchSize int
+}
+
+// NewClient creates a new Bedrock embeddings client.
+func NewClient(client *bedrockruntime.Client, modelID, inputType string, maxBatchSize int) *Client {
+	if maxBatchSize <= 0 {
+		maxBatchSize = defaultBatchSize(modelID)
+	}
+
+	return &Client{
+		client:       client,
+		modelID:      modelID,
+		inputType:    inputType,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+// Usage reports the token usage of an embeddings call.
+type Usage struct {
+	InputTokens int
+}
+
+// CreateEmbedding embeds texts in batches of at most maxBatchSize texts,
+// returning the embedding vectors in the same order as texts.
+func (c *Client) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, Usage, error) {
+	var vectors [][]float32
+	var usage Usage
+
+	for start := 0; start < len(texts); start += c.maxBatchSize {
+		end := start + c.maxBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batchVectors, batchUsage, err := c.invoke(ctx, texts[start:end])
+		if err != nil {
+			return nil, Usage{}, err
+		}
+
+		vectors = append(vectors, batchVectors...)
+		usage.InputTokens += batchUsage.InputTokens
+	}
+
+	return vectors, usage, nil
+}
+
+func (c *Client) invoke(ctx context.Context, texts []string) ([][]float32, Usage, error) {
+	body, err := c.requestBody(texts)
+	if err != nil {
+		return nil, Usage{}, err
+	}
+
+	output, err := c.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     aws.String(c.modelID),
+		ContentType: aws.String("application/json"),
+		Body:        body,
+	})
+	if err != nil {
+		return nil, Usage{}, err
+	}
+
+	return parseResponseBody(c.modelID, output.Body)
+}
+
+func (c *Client) requestBody(texts []string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(c.modelID, "amazon.titan-embed"):
+		if len(texts) != 1 {
+			return nil, fmt.Errorf("titan embed models accept exactly one input per call, got %d", len(texts))
+		}
+		return json.Marshal(titanRequest{InputText: texts[0]})
+	case strings.HasPrefix(c.modelID, "cohere.embed"):
+		inputType := c.inputType
+		if inputType == "" {
+			inputType = "search_document"
+		}
+		return json.Marshal(cohereRequest{Texts: texts, InputType: inputType})
+	}
+	return nil, fmt.Errorf("unsupported embeddings model: %s", c.modelID)
+}
+
+func parseResponseBody(modelID string, body []byte) ([][]float32, Usage, error) {
+	switch {
+	case strings.HasPrefix(modelID, "amazon.titan-embed"):
+		var resp titanResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, Usage{}, err
+		}
+		return [][]float32{resp.Embedding}, Usage{InputTokens: resp.InputTextTokenCount}, nil
+	case strings.HasPrefix(modelID, "cohere.embed"):
+		var resp cohereResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, Usage{}, err
+		}
+		return resp.Embeddings, Usage{InputTokens: resp.Meta.BilledUnits.InputTokens}, nil
+	}
+	return nil, Usage{}, fmt.Errorf("unsupported embeddings model: %s", modelID)
+}
+
+func defaultBatchSize(modelID string) int {
+	if strings.HasPrefix(modelID, "cohere.embed") {
+		return defaultCohereBatchSize
+	}
+	return defaultTitanBatchSize
+}
+
+type titanRequest struct {
+	InputText string `json:"inputText"`
+}
+
+type titanResponse struct {
+	Embedding           []float32 `json:"embedding"`
+	InputTextTokenCount int       `json:"inputTextTokenCount"`
+}
+
+type cohereRequest struct {
+	Texts     []string `json:"texts"`
+	InputType string   `json:"input_type"`
+}
+
+type cohereResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+	Meta       struct {
+		BilledUnits struct {
+			InputTokens int `json:"input_tokens"`
+		} `json:"billed_units"`
+	} `json:"meta"`
+}
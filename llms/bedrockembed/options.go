@@ -0,0 +1,45 @@
+package bedrockembed
+
+import "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+
+type options struct {
+	modelID      string
+	client       *bedrockruntime.Client
+	inputType    string
+	maxBatchSize int
+}
+
+// Option is an option for the Bedrock embeddings client.
+type Option func(*options)
+
+// WithModel allows setting a custom modelID.
+func WithModel(modelID string) Option {
+	return func(o *options) {
+		o.modelID = modelID
+	}
+}
+
+// WithClient allows setting a custom bedrockruntime.Client.
+func WithClient(client *bedrockruntime.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithInputType sets the Cohere "input_type" field ("search_document",
+// "search_query", "classification", or "clustering"). It has no effect on
+// models other than Cohere Embed.
+func WithInputType(inputType string) Option {
+	return func(o *options) {
+		o.inputType = inputType
+	}
+}
+
+// WithBatchSize overrides the number of texts sent per InvokeModel call.
+// When unset, a default is chosen based on the model's own limit (1 for
+// Titan Embed, 96 for Cohere Embed).
+func WithBatchSize(maxBatchSize int) Option {
+	return func(o *options) {
+		o.maxBatchSize = maxBatchSize
+	}
+}